@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	rateLimitBaseBackoff = 30 * time.Second
+	rateLimitMaxBackoff  = 10 * time.Minute
+)
+
+// DefaultMaxRateLimitRetries is the maxRetries value callers should pass to
+// RetryOnRateLimit unless they have a specific reason to tune it. Centralizing
+// it here means a future change to the default only has to happen once.
+const DefaultMaxRateLimitRetries = 3
+
+// RateLimitError is returned when a request is rejected because the
+// primary rate limit has been exhausted (HTTP 403 with
+// X-RateLimit-Remaining: 0).
+type RateLimitError struct {
+	StatusCode int
+	Message    string
+	Reset      time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return e.Message
+}
+
+// AbuseRateLimitError is returned for GitHub's secondary ("abuse")
+// rate limit, which carries an explicit Retry-After header instead of
+// the X-RateLimit-* reset headers.
+type AbuseRateLimitError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *AbuseRateLimitError) Error() string {
+	return e.Message
+}
+
+// RateLimitErrorFromResponse inspects a non-2xx response and, if it looks
+// like a primary or secondary rate limit response, returns the
+// corresponding error. It returns nil if resp doesn't look rate-limited.
+// Use this for direct-HTTP callers (e.g. artifact archive downloads) that
+// never go through client.REST and so never get an *HTTPError for free.
+func RateLimitErrorFromResponse(resp *http.Response, message string) error {
+	wait, kind := classifyRateLimit(resp.StatusCode, resp.Header)
+	switch kind {
+	case rateLimitKindAbuse:
+		return &AbuseRateLimitError{StatusCode: resp.StatusCode, Message: message, RetryAfter: wait}
+	case rateLimitKindPrimary:
+		reset := time.Time{}
+		if wait > 0 {
+			reset = time.Now().Add(wait)
+		}
+		return &RateLimitError{StatusCode: resp.StatusCode, Message: message, Reset: reset}
+	default:
+		return nil
+	}
+}
+
+type rateLimitKind int
+
+const (
+	rateLimitKindNone rateLimitKind = iota
+	rateLimitKindPrimary
+	rateLimitKindAbuse
+)
+
+// classifyRateLimit inspects a response's status code and headers for the
+// primary (X-RateLimit-Remaining: 0) or secondary/abuse (Retry-After) rate
+// limit shapes, returning how long to wait and which kind matched.
+func classifyRateLimit(statusCode int, headers http.Header) (time.Duration, rateLimitKind) {
+	if statusCode != http.StatusForbidden && statusCode != http.StatusTooManyRequests {
+		return 0, rateLimitKindNone
+	}
+
+	if retryAfter := headers.Get("Retry-After"); retryAfter != "" {
+		if d, ok := parseRetryAfter(retryAfter); ok {
+			return d, rateLimitKindAbuse
+		}
+	}
+
+	if headers.Get("X-RateLimit-Remaining") == "0" {
+		if resetUnix := headers.Get("X-RateLimit-Reset"); resetUnix != "" {
+			if sec, err := strconv.ParseInt(resetUnix, 10, 64); err == nil {
+				return time.Until(time.Unix(sec, 0)), rateLimitKindPrimary
+			}
+		}
+		return 0, rateLimitKindPrimary
+	}
+
+	return 0, rateLimitKindNone
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// RetryOnRateLimit calls fn, retrying with an appropriate delay whenever fn
+// returns a RateLimitError or AbuseRateLimitError, up to maxRetries times.
+// The Retry-After header (or X-RateLimit-Reset) drives the wait whenever
+// it's available; otherwise a capped exponential backoff is used. Passing
+// a non-nil errOut prints a one-line notice before any wait longer than 5s.
+// ctx allows the caller to cancel a pending wait.
+func RetryOnRateLimit(ctx context.Context, errOut io.Writer, maxRetries int, fn func() error) error {
+	backoff := rateLimitBaseBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		wait, retryable := rateLimitWait(err)
+		if !retryable {
+			return err
+		}
+		if attempt >= maxRetries {
+			return fmt.Errorf("exceeded %d retries: %w", maxRetries, err)
+		}
+
+		if wait <= 0 {
+			wait = backoff
+			backoff *= 2
+			if backoff > rateLimitMaxBackoff {
+				backoff = rateLimitMaxBackoff
+			}
+		}
+
+		if errOut != nil && wait > 5*time.Second {
+			fmt.Fprintf(errOut, "! GitHub API rate limit hit; waiting %s before retrying...\n", wait.Round(time.Second))
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// rateLimitWait reports how long to wait before retrying err, and whether
+// err looks rate-limited at all. client.REST itself returns *HTTPError on
+// non-2xx responses, so that's the shape we classify by headers; the
+// RateLimitError/AbuseRateLimitError types above only ever show up from
+// direct-HTTP callers that built them via RateLimitErrorFromResponse.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var abuseErr *AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return abuseErr.RetryAfter, true
+	}
+
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		if rateErr.Reset.IsZero() {
+			return 0, true
+		}
+		return time.Until(rateErr.Reset), true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		wait, kind := classifyRateLimit(httpErr.StatusCode, httpErr.Headers)
+		if kind != rateLimitKindNone {
+			return wait, true
+		}
+	}
+
+	return 0, false
+}
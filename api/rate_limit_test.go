@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "120", want: 120 * time.Second, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "garbage", value: "not-a-duration", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Fatalf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	future := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	got, ok := parseRetryAfter(future)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) ok = false, want true", future)
+	}
+	if got < 85*time.Second || got > 90*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = %v, want ~90s", future, got)
+	}
+}
+
+func TestClassifyRateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		headers    http.Header
+		wantKind   rateLimitKind
+	}{
+		{
+			name:       "not rate limited",
+			statusCode: http.StatusNotFound,
+			headers:    http.Header{},
+			wantKind:   rateLimitKindNone,
+		},
+		{
+			name:       "403 without rate limit headers",
+			statusCode: http.StatusForbidden,
+			headers:    http.Header{},
+			wantKind:   rateLimitKindNone,
+		},
+		{
+			name:       "abuse rate limit via Retry-After",
+			statusCode: http.StatusForbidden,
+			headers:    http.Header{"Retry-After": []string{"30"}},
+			wantKind:   rateLimitKindAbuse,
+		},
+		{
+			name:       "primary rate limit via X-RateLimit-Remaining",
+			statusCode: http.StatusForbidden,
+			headers: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{"9999999999"},
+			},
+			wantKind: rateLimitKindPrimary,
+		},
+		{
+			name:       "429 also recognized",
+			statusCode: http.StatusTooManyRequests,
+			headers:    http.Header{"Retry-After": []string{"5"}},
+			wantKind:   rateLimitKindAbuse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, kind := classifyRateLimit(tt.statusCode, tt.headers)
+			if kind != tt.wantKind {
+				t.Fatalf("classifyRateLimit() kind = %v, want %v", kind, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRateLimitWaitMatchesHTTPError(t *testing.T) {
+	err := &HTTPError{
+		StatusCode: http.StatusForbidden,
+		Headers: http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{"9999999999"},
+		},
+	}
+
+	wait, retryable := rateLimitWait(err)
+	if !retryable {
+		t.Fatalf("rateLimitWait() retryable = false, want true for rate-limited *HTTPError")
+	}
+	if wait <= 0 {
+		t.Fatalf("rateLimitWait() wait = %v, want > 0", wait)
+	}
+}
+
+func TestRateLimitWaitIgnoresOrdinaryHTTPError(t *testing.T) {
+	err := &HTTPError{StatusCode: http.StatusNotFound, Headers: http.Header{}}
+
+	_, retryable := rateLimitWait(err)
+	if retryable {
+		t.Fatalf("rateLimitWait() retryable = true, want false for a plain 404")
+	}
+}
+
+func TestRetryOnRateLimitRetriesThenSucceeds(t *testing.T) {
+	attempts := 0
+	err := RetryOnRateLimit(context.Background(), nil, 3, func() error {
+		attempts++
+		if attempts < 3 {
+			return &AbuseRateLimitError{RetryAfter: time.Millisecond}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RetryOnRateLimit() error = %v, want nil", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryOnRateLimitGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	sentinel := &AbuseRateLimitError{RetryAfter: time.Millisecond}
+	err := RetryOnRateLimit(context.Background(), nil, 2, func() error {
+		attempts++
+		return sentinel
+	})
+	if err == nil {
+		t.Fatal("RetryOnRateLimit() error = nil, want non-nil after exceeding retries")
+	}
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("RetryOnRateLimit() error = %v, want it to wrap the underlying error", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3 (initial + 2 retries)", attempts)
+	}
+}
+
+func TestRetryOnRateLimitReturnsNonRateLimitedErrorImmediately(t *testing.T) {
+	attempts := 0
+	plain := errors.New("not found")
+	err := RetryOnRateLimit(context.Background(), nil, 3, func() error {
+		attempts++
+		return plain
+	})
+	if !errors.Is(err, plain) {
+		t.Fatalf("RetryOnRateLimit() error = %v, want %v", err, plain)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1 (no retries for a non-rate-limit error)", attempts)
+	}
+}
+
+func TestRetryOnRateLimitRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := RetryOnRateLimit(ctx, nil, 3, func() error {
+		attempts++
+		return &AbuseRateLimitError{RetryAfter: time.Hour}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RetryOnRateLimit() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
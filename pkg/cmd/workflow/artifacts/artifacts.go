@@ -0,0 +1,215 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+const defaultOutputDir = "artifacts"
+
+type Artifact struct {
+	ID                 int64
+	Name               string
+	SizeInBytes        int64  `json:"size_in_bytes"`
+	ArchiveDownloadURL string `json:"archive_download_url"`
+}
+
+type artifactsPayload struct {
+	Artifacts []Artifact
+}
+
+type ArtifactsOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	RunID     int64
+	OutputDir string
+}
+
+func NewCmdArtifacts(f *cmdutil.Factory, runF func(*ArtifactsOptions) error) *cobra.Command {
+	opts := &ArtifactsOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "artifacts <run-id>",
+		Short: "Download the artifacts produced by a workflow run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+
+			runID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid run ID: %v", args[0])}
+			}
+			opts.RunID = runID
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return artifactsRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", defaultOutputDir, "Directory to extract artifacts into")
+
+	return cmd
+}
+
+func artifactsRun(opts *ArtifactsOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	defer opts.IO.StopProgressIndicator()
+
+	path := fmt.Sprintf("repos/%s/actions/runs/%d/artifacts", ghrepo.FullName(repo), opts.RunID)
+	var payload artifactsPayload
+	err = api.RetryOnRateLimit(opts.Context, opts.IO.ErrOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", path, nil, &payload)
+	})
+	if err != nil {
+		return fmt.Errorf("could not list artifacts: %w", err)
+	}
+
+	for _, artifact := range payload.Artifacts {
+		if err := downloadArtifact(opts.Context, opts.IO.ErrOut, httpClient, artifact, opts.OutputDir); err != nil {
+			return fmt.Errorf("could not download artifact %q: %w", artifact.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// downloadArtifact streams the artifact's zip archive and extracts it under
+// destDir/<artifact.Name>, refusing to extract any entry that would escape
+// that directory. The archive fetch goes through httpClient.Do directly
+// rather than client.REST, so it's retried here rather than getting
+// rate-limit handling for free.
+func downloadArtifact(ctx context.Context, errOut io.Writer, httpClient *http.Client, artifact Artifact, destDir string) error {
+	req, err := http.NewRequest("GET", artifact.ArchiveDownloadURL, nil)
+	if err != nil {
+		return err
+	}
+
+	var resp *http.Response
+	err = api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		r, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		if r.StatusCode != http.StatusOK {
+			defer r.Body.Close()
+			message := fmt.Sprintf("failed to download artifact %q", artifact.Name)
+			if rlErr := api.RateLimitErrorFromResponse(r, message); rlErr != nil {
+				return rlErr
+			}
+			return api.HandleHTTPError(r)
+		}
+
+		resp = r
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var readerAt io.ReaderAt
+	var size int64
+
+	if artifact.SizeInBytes > 0 && artifact.SizeInBytes < 50*1024*1024 {
+		buf := new(bytes.Buffer)
+		if _, err := io.Copy(buf, resp.Body); err != nil {
+			return err
+		}
+		readerAt = bytes.NewReader(buf.Bytes())
+		size = int64(buf.Len())
+	} else {
+		tmp, err := os.CreateTemp("", "gh-artifact-*.zip")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		n, err := io.Copy(tmp, resp.Body)
+		if err != nil {
+			return err
+		}
+		readerAt = tmp
+		size = n
+	}
+
+	zr, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return err
+	}
+
+	outDir := filepath.Join(destDir, artifact.Name)
+	for _, f := range zr.File {
+		if err := extractZipFile(f, outDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(f *zip.File, outDir string) error {
+	target := filepath.Join(outDir, f.Name)
+	if !strings.HasPrefix(target, filepath.Clean(outDir)+string(os.PathSeparator)) {
+		return fmt.Errorf("illegal file path in archive: %s", f.Name)
+	}
+
+	if f.FileInfo().IsDir() {
+		return os.MkdirAll(target, 0755)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
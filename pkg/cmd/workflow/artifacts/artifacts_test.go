@@ -0,0 +1,74 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildZip(t *testing.T, entries map[string]string) *zip.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zw.Create(%q) error = %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("write entry %q error = %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zw.Close() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	return zr
+}
+
+func TestExtractZipFileWritesWithinOutputDir(t *testing.T) {
+	outDir := t.TempDir()
+	zr := buildZip(t, map[string]string{"result.txt": "hello"})
+
+	if err := extractZipFile(zr.File[0], outDir); err != nil {
+		t.Fatalf("extractZipFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "result.txt"))
+	if err != nil {
+		t.Fatalf("reading extracted file: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("extracted content = %q, want %q", data, "hello")
+	}
+}
+
+func TestExtractZipFileRejectsZipSlip(t *testing.T) {
+	outDir := t.TempDir()
+	zr := buildZip(t, map[string]string{"../../etc/passwd": "pwned"})
+
+	err := extractZipFile(zr.File[0], outDir)
+	if err == nil {
+		t.Fatal("extractZipFile() error = nil, want an error for a path escaping outDir")
+	}
+
+	if _, statErr := os.Stat(filepath.Join(filepath.Dir(filepath.Dir(outDir)), "etc", "passwd")); statErr == nil {
+		t.Fatal("zip-slip entry was written outside outDir")
+	}
+}
+
+func TestExtractZipFileRejectsNestedTraversal(t *testing.T) {
+	outDir := t.TempDir()
+	zr := buildZip(t, map[string]string{"sibling/../../escape.txt": "pwned"})
+
+	if err := extractZipFile(zr.File[0], outDir); err == nil {
+		t.Fatal("extractZipFile() error = nil, want an error for a path escaping outDir")
+	}
+}
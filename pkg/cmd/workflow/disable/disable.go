@@ -0,0 +1,105 @@
+package disable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type DisableOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	Selector string
+	Yes      bool
+}
+
+func NewCmdDisable(f *cmdutil.Factory, runF func(*DisableOptions) error) *cobra.Command {
+	opts := &DisableOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "disable <workflow-id | workflow-name | workflow-file>",
+		Short: "Disable a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+			opts.Selector = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return disableRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Disable without prompting for confirmation")
+
+	return cmd
+}
+
+func disableRun(opts *DisableOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	workflow, err := shared.ResolveWorkflow(opts.Context, opts.IO.ErrOut, client, repo, opts.Selector)
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not resolve workflow %q: %w", opts.Selector, err)
+	}
+
+	if !opts.Yes && opts.IO.CanPrompt() {
+		confirmed := false
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Disable %s?", workflow.Name),
+			Default: false,
+		}, &confirmed)
+		if err != nil {
+			return fmt.Errorf("could not prompt: %w", err)
+		}
+		if !confirmed {
+			return cmdutil.CancelError
+		}
+	}
+
+	path := fmt.Sprintf("repos/%s/actions/workflows/%d/disable", ghrepo.FullName(repo), workflow.ID)
+
+	opts.IO.StartProgressIndicator()
+	err = api.RetryOnRateLimit(opts.Context, opts.IO.ErrOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "PUT", path, nil, nil)
+	})
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not disable workflow: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.Out, "%s Disabled %s\n", cs.SuccessIcon(), workflow.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package enable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type EnableOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	Selector string
+}
+
+func NewCmdEnable(f *cmdutil.Factory, runF func(*EnableOptions) error) *cobra.Command {
+	opts := &EnableOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "enable <workflow-id | workflow-name | workflow-file>",
+		Short: "Enable a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+			opts.Selector = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return enableRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func enableRun(opts *EnableOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	workflow, err := shared.ResolveWorkflow(opts.Context, opts.IO.ErrOut, client, repo, opts.Selector)
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not resolve workflow %q: %w", opts.Selector, err)
+	}
+
+	path := fmt.Sprintf("repos/%s/actions/workflows/%d/enable", ghrepo.FullName(repo), workflow.ID)
+
+	opts.IO.StartProgressIndicator()
+	err = api.RetryOnRateLimit(opts.Context, opts.IO.ErrOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "PUT", path, nil, nil)
+	})
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not enable workflow: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		cs := opts.IO.ColorScheme()
+		fmt.Fprintf(opts.IO.Out, "%s Enabled %s\n", cs.SuccessIcon(), workflow.Name)
+	}
+
+	return nil
+}
@@ -1,33 +1,33 @@
 package list
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/cli/cli/api"
 	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
 	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/cmdutil/jsonexport"
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/cli/cli/utils"
 	"github.com/spf13/cobra"
 )
 
-const (
-	defaultLimit = 10
-
-	Active           WorkflowState = "active"
-	DisabledManually WorkflowState = "disabled_manually"
-)
+const defaultLimit = 10
 
 type ListOptions struct {
 	IO         *iostreams.IOStreams
 	HttpClient func() (*http.Client, error)
 	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
 
 	PlainOutput bool
 
-	All   bool
-	Limit int
+	All      bool
+	Limit    int
+	Exporter *jsonexport.Exporter
 }
 
 func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
@@ -44,6 +44,7 @@ func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Comman
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// support `-R, --repo` override
 			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
 
 			terminal := opts.IO.IsStdoutTTY() && opts.IO.IsStdinTTY()
 			opts.PlainOutput = !terminal
@@ -62,6 +63,7 @@ func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Comman
 
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", defaultLimit, "Maximum number of workflows to fetch")
 	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Show all workflows, including disabled workflows")
+	jsonexport.AddFlags(cmd, &opts.Exporter, shared.JSONFields)
 
 	return cmd
 }
@@ -79,19 +81,30 @@ func listRun(opts *ListOptions) error {
 	client := api.NewClientFromHTTP(httpClient)
 
 	opts.IO.StartProgressIndicator()
-	workflows, err := getWorkflows(client, repo, opts.Limit)
+	workflows, err := shared.GetWorkflows(opts.Context, opts.IO.ErrOut, client, repo, opts.Limit)
 	opts.IO.StopProgressIndicator()
 	if err != nil {
 		return fmt.Errorf("could not get workflows: %w", err)
 	}
 
-	if len(workflows) == 0 {
+	if len(workflows) == 0 && opts.Exporter == nil {
 		if !opts.PlainOutput {
 			fmt.Fprintln(opts.IO.ErrOut, "No workflows found")
 		}
 		return nil
 	}
 
+	if opts.Exporter != nil {
+		items := make([]jsonexport.Exportable, 0, len(workflows))
+		for i := range workflows {
+			if workflows[i].Disabled() && !opts.All {
+				continue
+			}
+			items = append(items, &workflows[i])
+		}
+		return opts.Exporter.Write(opts.IO.Out, items)
+	}
+
 	tp := utils.NewTablePrinter(opts.IO)
 	cs := opts.IO.ColorScheme()
 
@@ -107,55 +120,3 @@ func listRun(opts *ListOptions) error {
 
 	return tp.Render()
 }
-
-type WorkflowState string
-
-type Workflow struct {
-	Name  string
-	ID    int
-	State WorkflowState
-}
-
-func (w *Workflow) Disabled() bool {
-	return w.State != Active
-}
-
-type WorkflowsPayload struct {
-	Workflows []Workflow
-}
-
-func getWorkflows(client *api.Client, repo ghrepo.Interface, limit int) ([]Workflow, error) {
-	perPage := limit
-	page := 1
-	if limit > 100 {
-		perPage = 100
-	}
-
-	workflows := []Workflow{}
-
-	for len(workflows) < limit {
-		var result WorkflowsPayload
-
-		path := fmt.Sprintf("repos/%s/actions/workflows?per_page=%d&page=%d", ghrepo.FullName(repo), perPage, page)
-
-		err := client.REST(repo.RepoHost(), "GET", path, nil, &result)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, workflow := range result.Workflows {
-			workflows = append(workflows, workflow)
-			if len(workflows) == limit {
-				break
-			}
-		}
-
-		if len(result.Workflows) < perPage {
-			break
-		}
-
-		page++
-	}
-
-	return workflows, nil
-}
@@ -0,0 +1,423 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+const (
+	pollWindow   = 15 * time.Second
+	pollInterval = 2 * time.Second
+)
+
+type RunOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	Selector string
+	Ref      string
+
+	RawFields   []string // -f key=value
+	MagicFields []string // -F key=value, @file reads from disk
+	JSONInputs  string   // --json <path>, "-" for stdin
+}
+
+func NewCmdRun(f *cmdutil.Factory, runF func(*RunOptions) error) *cobra.Command {
+	opts := &RunOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "run <workflow-id | workflow-name | workflow-file>",
+		Short: "Create a workflow_dispatch event for a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+			opts.Selector = args[0]
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return runRun(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Ref, "ref", "", "Branch or tag to run the workflow on (defaults to the repo's default branch)")
+	cmd.Flags().StringArrayVarP(&opts.RawFields, "raw-field", "f", nil, "Add an input in key=value format")
+	cmd.Flags().StringArrayVarP(&opts.MagicFields, "field", "F", nil, "Add an input in key=value format; use @file to read the value from a file")
+	cmd.Flags().StringVar(&opts.JSONInputs, "json", "", "Read inputs as a JSON object from a file (\"-\" for stdin)")
+
+	return cmd
+}
+
+func runRun(opts *RunOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	workflow, err := shared.ResolveWorkflow(opts.Context, opts.IO.ErrOut, client, repo, opts.Selector)
+	if err != nil {
+		opts.IO.StopProgressIndicator()
+		return fmt.Errorf("could not resolve workflow %q: %w", opts.Selector, err)
+	}
+
+	yamlContent, err := getWorkflowContent(opts.Context, opts.IO.ErrOut, client, repo, workflow.Path, opts.Ref)
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not fetch workflow file: %w", err)
+	}
+
+	declaredInputs, dispatchable, err := shared.ParseDispatchInputs(yamlContent)
+	if err != nil {
+		return fmt.Errorf("could not parse workflow file: %w", err)
+	}
+	if !dispatchable {
+		return fmt.Errorf("workflow %q does not declare a workflow_dispatch trigger", workflow.Name)
+	}
+
+	inputs, err := gatherInputs(opts, client, repo, declaredInputs)
+	if err != nil {
+		return err
+	}
+
+	ref := opts.Ref
+	if ref == "" {
+		opts.IO.StartProgressIndicator()
+		ref, err = getDefaultBranch(opts.Context, opts.IO.ErrOut, client, repo)
+		opts.IO.StopProgressIndicator()
+		if err != nil {
+			return fmt.Errorf("could not determine the repo's default branch: %w", err)
+		}
+	}
+
+	if err := dispatch(opts.Context, opts.IO.ErrOut, client, repo, workflow.ID, ref, inputs); err != nil {
+		return fmt.Errorf("could not create workflow_dispatch event: %w", err)
+	}
+
+	cs := opts.IO.ColorScheme()
+	fmt.Fprintf(opts.IO.Out, "%s Created a workflow_dispatch event for %s\n", cs.SuccessIcon(), workflow.Name)
+
+	dispatchedAt := time.Now().Add(-5 * time.Second) // REST clocks aren't perfectly in sync
+	opts.IO.StartProgressIndicator()
+	run, err := awaitDispatchedRun(opts.Context, opts.IO.ErrOut, client, repo, workflow.ID, dispatchedAt)
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not look up the new run: %w", err)
+	}
+
+	if run != nil {
+		fmt.Fprintf(opts.IO.Out, "%s\n", run.HTMLURL)
+	} else {
+		fmt.Fprintln(opts.IO.Out, "The run hasn't appeared yet; check the workflow's runs shortly.")
+	}
+
+	return nil
+}
+
+type repoContent struct {
+	Content  string
+	Encoding string
+}
+
+func getWorkflowContent(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, path string, ref string) ([]byte, error) {
+	p := fmt.Sprintf("repos/%s/contents/%s", ghrepo.FullName(repo), path)
+	if ref != "" {
+		p += "?ref=" + url.QueryEscape(ref)
+	}
+
+	var content repoContent
+	err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", p, nil, &content)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode workflow file contents: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// getDefaultBranch looks up repo's default branch, for use as the dispatch
+// ref when the user doesn't pass --ref explicitly. The dispatches endpoint
+// requires an actual branch or tag name; it has no "HEAD" equivalent.
+func getDefaultBranch(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface) (string, error) {
+	path := fmt.Sprintf("repos/%s", ghrepo.FullName(repo))
+
+	var result struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.DefaultBranch, nil
+}
+
+// dispatch creates a workflow_dispatch event for workflowID at ref, which
+// must be an existing branch or tag name.
+func dispatch(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, workflowID int, ref string, inputs map[string]string) error {
+	payload := struct {
+		Ref    string            `json:"ref"`
+		Inputs map[string]string `json:"inputs,omitempty"`
+	}{Ref: ref, Inputs: inputs}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/actions/workflows/%d/dispatches", ghrepo.FullName(repo), workflowID)
+
+	return api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "POST", path, bytes.NewReader(body), nil)
+	})
+}
+
+// awaitDispatchedRun polls for a run of workflowID created after since,
+// for a short window, since GitHub's dispatch endpoint returns 204 with
+// no indication of which run it created.
+func awaitDispatchedRun(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, workflowID int, since time.Time) (*shared.Run, error) {
+	login, err := api.CurrentLoginName(client, repo.RepoHost())
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(pollWindow)
+	for {
+		path := fmt.Sprintf("repos/%s/actions/workflows/%d/runs?event=workflow_dispatch&actor=%s&per_page=10",
+			ghrepo.FullName(repo), workflowID, url.QueryEscape(login))
+
+		var result shared.RunsPayload
+		err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+			return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range result.WorkflowRuns {
+			if run.CreatedAt.After(since) {
+				r := run
+				return &r, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, nil
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func gatherInputs(opts *RunOptions, client *api.Client, repo ghrepo.Interface, declared map[string]shared.DispatchInput) (map[string]string, error) {
+	if opts.JSONInputs != "" {
+		return inputsFromJSON(opts)
+	}
+
+	if len(opts.RawFields) > 0 || len(opts.MagicFields) > 0 {
+		return inputsFromFlags(opts)
+	}
+
+	if !opts.IO.CanPrompt() {
+		return defaultInputs(declared)
+	}
+
+	return promptInputs(opts.Context, opts.IO.ErrOut, client, repo, declared)
+}
+
+func inputsFromJSON(opts *RunOptions) (map[string]string, error) {
+	var raw []byte
+	var err error
+	if opts.JSONInputs == "-" {
+		raw, err = ioutil.ReadAll(opts.IO.In)
+	} else {
+		raw, err = ioutil.ReadFile(opts.JSONInputs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read JSON inputs: %w", err)
+	}
+
+	inputs := map[string]string{}
+	if err := json.Unmarshal(raw, &inputs); err != nil {
+		return nil, fmt.Errorf("could not parse JSON inputs: %w", err)
+	}
+	return inputs, nil
+}
+
+func inputsFromFlags(opts *RunOptions) (map[string]string, error) {
+	inputs := map[string]string{}
+
+	for _, pair := range opts.RawFields {
+		key, value, err := splitKeyValue(pair)
+		if err != nil {
+			return nil, err
+		}
+		inputs[key] = value
+	}
+
+	for _, pair := range opts.MagicFields {
+		key, value, err := splitKeyValue(pair)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(value, "@") {
+			data, err := ioutil.ReadFile(strings.TrimPrefix(value, "@"))
+			if err != nil {
+				return nil, fmt.Errorf("could not read file for %q: %w", key, err)
+			}
+			value = string(data)
+		}
+		inputs[key] = value
+	}
+
+	return inputs, nil
+}
+
+func splitKeyValue(pair string) (string, string, error) {
+	idx := strings.IndexRune(pair, '=')
+	if idx < 0 {
+		return "", "", &cmdutil.FlagError{Err: fmt.Errorf("invalid key=value pair: %q", pair)}
+	}
+	return pair[:idx], pair[idx+1:], nil
+}
+
+func defaultInputs(declared map[string]shared.DispatchInput) (map[string]string, error) {
+	inputs := map[string]string{}
+	var missing []string
+
+	for name, in := range declared {
+		if in.Default != "" {
+			inputs[name] = in.Default
+		} else if in.Required {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing required input(s): %s (use -f/-F or --json in non-interactive mode)", strings.Join(missing, ", "))
+	}
+
+	return inputs, nil
+}
+
+func promptInputs(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, declared map[string]shared.DispatchInput) (map[string]string, error) {
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	inputs := map[string]string{}
+	for _, name := range names {
+		value, err := promptInput(ctx, errOut, client, repo, name, declared[name])
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = value
+	}
+
+	return inputs, nil
+}
+
+func promptInput(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, name string, in shared.DispatchInput) (string, error) {
+	message := name
+	if in.Description != "" {
+		message = fmt.Sprintf("%s (%s)", name, in.Description)
+	}
+
+	switch in.Type {
+	case "boolean":
+		answer := in.Default == "true"
+		err := survey.AskOne(&survey.Confirm{Message: message, Default: answer}, &answer)
+		return strconv.FormatBool(answer), err
+
+	case "choice":
+		var answer string
+		err := survey.AskOne(&survey.Select{Message: message, Options: in.Options, Default: in.Default}, &answer)
+		return answer, err
+
+	case "environment":
+		names, err := listEnvironmentNames(ctx, errOut, client, repo)
+		if err != nil {
+			return "", fmt.Errorf("could not list environments: %w", err)
+		}
+		var answer string
+		err = survey.AskOne(&survey.Select{Message: message, Options: names, Default: in.Default}, &answer)
+		return answer, err
+
+	default: // "string" or unset
+		var answer string
+		err := survey.AskOne(&survey.Input{Message: message, Default: in.Default}, &answer)
+		return answer, err
+	}
+}
+
+// listEnvironmentNames fetches the names of the repo's configured
+// deployment environments, for constraining an "environment" input to a
+// survey.Select the same way a "choice" input's declared options would.
+func listEnvironmentNames(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface) ([]string, error) {
+	path := fmt.Sprintf("repos/%s/environments", ghrepo.FullName(repo))
+
+	var result struct {
+		Environments []struct {
+			Name string `json:"name"`
+		} `json:"environments"`
+	}
+	err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(result.Environments))
+	for i, e := range result.Environments {
+		names[i] = e.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,107 @@
+package runs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/cli/cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const defaultLimit = 20
+
+type RunsOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	WorkflowSelector string
+	Limit            int
+}
+
+func NewCmdRuns(f *cmdutil.Factory, runF func(*RunsOptions) error) *cobra.Command {
+	opts := &RunsOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "runs <workflow-id>",
+		Short: "List runs for a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+			opts.WorkflowSelector = args[0]
+
+			if opts.Limit < 1 {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid limit: %v", opts.Limit)}
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return runsRun(opts)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", defaultLimit, "Maximum number of runs to fetch")
+
+	return cmd
+}
+
+func runsRun(opts *RunsOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	workflow, err := shared.ResolveWorkflow(opts.Context, opts.IO.ErrOut, client, repo, opts.WorkflowSelector)
+	if err == nil {
+		var runs []shared.Run
+		runs, err = shared.GetWorkflowRuns(opts.Context, opts.IO.ErrOut, client, repo, workflow.ID, opts.Limit)
+		opts.IO.StopProgressIndicator()
+		if err != nil {
+			return fmt.Errorf("could not get runs: %w", err)
+		}
+		return printRuns(opts, runs)
+	}
+	opts.IO.StopProgressIndicator()
+
+	return fmt.Errorf("could not resolve workflow %q: %w", opts.WorkflowSelector, err)
+}
+
+func printRuns(opts *RunsOptions, runs []shared.Run) error {
+	if len(runs) == 0 {
+		fmt.Fprintln(opts.IO.ErrOut, "No runs found")
+		return nil
+	}
+
+	tp := utils.NewTablePrinter(opts.IO)
+	cs := opts.IO.ColorScheme()
+
+	for _, run := range runs {
+		tp.AddField(run.Status, nil, cs.Bold)
+		tp.AddField(run.Conclusion, nil, nil)
+		tp.AddField(run.HeadBranch, nil, cs.Cyan)
+		tp.AddField(fmt.Sprintf("%d", run.ID), nil, nil)
+		tp.EndRow()
+	}
+
+	return tp.Render()
+}
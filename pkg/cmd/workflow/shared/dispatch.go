@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// DispatchInput is one entry of a workflow's `on.workflow_dispatch.inputs`
+// map, as declared in the workflow YAML.
+type DispatchInput struct {
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Default     string   `yaml:"default"`
+	Type        string   `yaml:"type"` // string, choice, boolean, environment
+	Options     []string `yaml:"options"`
+}
+
+// ParseDispatchInputs reads a workflow YAML document and returns the
+// inputs declared under `on.workflow_dispatch.inputs`, along with whether
+// the workflow supports workflow_dispatch at all. It walks yaml.Node
+// directly (rather than unmarshaling into map[string]interface{}) because
+// the unquoted `on` key is otherwise resolved as the boolean `true` under
+// the YAML 1.1 core schema that workflow files are written against.
+func ParseDispatchInputs(data []byte) (map[string]DispatchInput, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, false, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, false, nil
+	}
+
+	onNode := mappingLookup(root, "on", "true", "yes")
+	if onNode == nil {
+		return nil, false, nil
+	}
+
+	switch onNode.Kind {
+	case yaml.ScalarNode:
+		return nil, onNode.Value == "workflow_dispatch", nil
+
+	case yaml.SequenceNode:
+		for _, item := range onNode.Content {
+			if item.Value == "workflow_dispatch" {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+
+	case yaml.MappingNode:
+		dispatchNode := mappingLookup(onNode, "workflow_dispatch")
+		if dispatchNode == nil {
+			return nil, false, nil
+		}
+		if dispatchNode.Kind != yaml.MappingNode {
+			return map[string]DispatchInput{}, true, nil
+		}
+
+		inputsNode := mappingLookup(dispatchNode, "inputs")
+		if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+			return map[string]DispatchInput{}, true, nil
+		}
+
+		inputs := map[string]DispatchInput{}
+		for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+			name := inputsNode.Content[i].Value
+			var in DispatchInput
+			if err := inputsNode.Content[i+1].Decode(&in); err != nil {
+				return nil, true, err
+			}
+			inputs[name] = in
+		}
+		return inputs, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func mappingLookup(node *yaml.Node, keys ...string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		for _, key := range keys {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package shared
+
+import "testing"
+
+func TestParseDispatchInputsUnquotedOnKey(t *testing.T) {
+	// `on:` is unquoted here, which under the YAML 1.1 core schema that
+	// go-yaml resolves scalars against would otherwise decode as the
+	// boolean `true` if read through map[string]interface{}.
+	yaml := []byte(`
+name: CI
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        required: true
+        type: choice
+        options:
+          - staging
+          - production
+      dry_run:
+        description: Skip the actual deploy
+        required: false
+        default: "false"
+        type: boolean
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	inputs, dispatchable, err := ParseDispatchInputs(yaml)
+	if err != nil {
+		t.Fatalf("ParseDispatchInputs() error = %v", err)
+	}
+	if !dispatchable {
+		t.Fatal("ParseDispatchInputs() dispatchable = false, want true")
+	}
+	if len(inputs) != 2 {
+		t.Fatalf("len(inputs) = %d, want 2", len(inputs))
+	}
+
+	env, ok := inputs["environment"]
+	if !ok {
+		t.Fatal(`inputs["environment"] missing`)
+	}
+	if env.Type != "choice" || !env.Required || len(env.Options) != 2 {
+		t.Fatalf("environment input = %+v, unexpected shape", env)
+	}
+
+	dryRun, ok := inputs["dry_run"]
+	if !ok {
+		t.Fatal(`inputs["dry_run"] missing`)
+	}
+	if dryRun.Type != "boolean" || dryRun.Default != "false" {
+		t.Fatalf("dry_run input = %+v, unexpected shape", dryRun)
+	}
+}
+
+func TestParseDispatchInputsNoInputsDeclared(t *testing.T) {
+	yaml := []byte(`
+on:
+  workflow_dispatch: {}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	inputs, dispatchable, err := ParseDispatchInputs(yaml)
+	if err != nil {
+		t.Fatalf("ParseDispatchInputs() error = %v", err)
+	}
+	if !dispatchable {
+		t.Fatal("ParseDispatchInputs() dispatchable = false, want true")
+	}
+	if len(inputs) != 0 {
+		t.Fatalf("len(inputs) = %d, want 0", len(inputs))
+	}
+}
+
+func TestParseDispatchInputsListOfTriggers(t *testing.T) {
+	yaml := []byte(`
+on: [push, workflow_dispatch]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	_, dispatchable, err := ParseDispatchInputs(yaml)
+	if err != nil {
+		t.Fatalf("ParseDispatchInputs() error = %v", err)
+	}
+	if !dispatchable {
+		t.Fatal("ParseDispatchInputs() dispatchable = false, want true")
+	}
+}
+
+func TestParseDispatchInputsNotDispatchable(t *testing.T) {
+	yaml := []byte(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	_, dispatchable, err := ParseDispatchInputs(yaml)
+	if err != nil {
+		t.Fatalf("ParseDispatchInputs() error = %v", err)
+	}
+	if dispatchable {
+		t.Fatal("ParseDispatchInputs() dispatchable = true, want false")
+	}
+}
@@ -0,0 +1,120 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+)
+
+// Run represents a single execution of a workflow.
+type Run struct {
+	ID         int64
+	Name       string
+	WorkflowID int    `json:"workflow_id"`
+	Status     string // queued, in_progress, completed
+	Conclusion string // success, failure, cancelled, ...
+	Event      string
+	HeadBranch string    `json:"head_branch"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RunsPayload struct {
+	WorkflowRuns []Run `json:"workflow_runs"`
+}
+
+// Step is a single step of a job within a run.
+type Step struct {
+	Name       string
+	Status     string
+	Conclusion string
+	Number     int
+}
+
+// Job is a single job of a run, made up of one or more steps.
+type Job struct {
+	ID         int64
+	Name       string
+	Status     string
+	Conclusion string
+	Steps      []Step
+	HTMLURL    string `json:"html_url"`
+}
+
+type JobsPayload struct {
+	Jobs []Job
+}
+
+// GetWorkflowRuns fetches up to limit runs for the given workflow,
+// paginating as needed (mirrors GetWorkflows in shared.go).
+func GetWorkflowRuns(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, workflowID int, limit int) ([]Run, error) {
+	perPage := limit
+	page := 1
+	if limit > 100 {
+		perPage = 100
+	}
+
+	runs := []Run{}
+
+	for len(runs) < limit {
+		var result RunsPayload
+
+		path := fmt.Sprintf("repos/%s/actions/workflows/%d/runs?per_page=%d&page=%d", ghrepo.FullName(repo), workflowID, perPage, page)
+
+		err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+			return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range result.WorkflowRuns {
+			runs = append(runs, run)
+			if len(runs) == limit {
+				break
+			}
+		}
+
+		if len(result.WorkflowRuns) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return runs, nil
+}
+
+// GetRun fetches a single run by ID.
+func GetRun(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, runID int64) (*Run, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs/%d", ghrepo.FullName(repo), runID)
+
+	var run Run
+	err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", path, nil, &run)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// GetRunJobs fetches the jobs (and their steps) that belong to a run.
+func GetRunJobs(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, runID int64) ([]Job, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs/%d/jobs", ghrepo.FullName(repo), runID)
+
+	var result JobsPayload
+	err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+		return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Jobs, nil
+}
@@ -0,0 +1,167 @@
+// Package shared holds the types and API helpers that are common to the
+// various `gh workflow` subcommands (list, runs, view, artifacts, ...).
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+)
+
+type WorkflowState string
+
+const (
+	Active           WorkflowState = "active"
+	DisabledManually WorkflowState = "disabled_manually"
+)
+
+type Workflow struct {
+	Name      string
+	ID        int
+	Path      string
+	State     WorkflowState
+	URL       string    `json:"url"`
+	BadgeURL  string    `json:"badge_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w *Workflow) Disabled() bool {
+	return w.State != Active
+}
+
+// ExportData implements jsonexport.Exportable so Workflow can be rendered
+// by the --json/--jq/--template flags.
+func (w *Workflow) ExportData(fields []string) map[string]interface{} {
+	v := map[string]interface{}{}
+	for _, field := range fields {
+		switch field {
+		case "name":
+			v[field] = w.Name
+		case "id":
+			v[field] = w.ID
+		case "path":
+			v[field] = w.Path
+		case "state":
+			v[field] = string(w.State)
+		case "url":
+			v[field] = w.URL
+		case "badge_url":
+			v[field] = w.BadgeURL
+		case "created_at":
+			v[field] = w.CreatedAt
+		case "updated_at":
+			v[field] = w.UpdatedAt
+		}
+	}
+	return v
+}
+
+// JSONFields lists the field names that are valid for Workflow's --json
+// output.
+var JSONFields = []string{"name", "id", "state", "path", "url", "badge_url", "created_at", "updated_at"}
+
+type WorkflowsPayload struct {
+	Workflows []Workflow
+}
+
+// GetWorkflows fetches up to limit workflows for repo, paginating as needed.
+func GetWorkflows(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, limit int) ([]Workflow, error) {
+	perPage := limit
+	page := 1
+	if limit > 100 {
+		perPage = 100
+	}
+
+	workflows := []Workflow{}
+
+	for len(workflows) < limit {
+		var result WorkflowsPayload
+
+		path := fmt.Sprintf("repos/%s/actions/workflows?per_page=%d&page=%d", ghrepo.FullName(repo), perPage, page)
+
+		err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+			return client.REST(repo.RepoHost(), "GET", path, nil, &result)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workflow := range result.Workflows {
+			workflows = append(workflows, workflow)
+			if len(workflows) == limit {
+				break
+			}
+		}
+
+		if len(result.Workflows) < perPage {
+			break
+		}
+
+		page++
+	}
+
+	return workflows, nil
+}
+
+// maxResolvePages bounds how many pages ResolveWorkflow will fetch while
+// searching for a name/path match, so a typo can't spin forever against a
+// repo with an enormous number of workflows.
+const maxResolvePages = 20
+
+// ResolveWorkflow finds a single workflow by numeric ID, by exact
+// case-insensitive name match, or by its file path (either the full path
+// such as ".github/workflows/foo.yml", or just "foo.yml"). Unlike
+// GetWorkflows, it keeps paginating past --limit until it finds a match,
+// exhausts the repo's workflows, or has fetched maxResolvePages pages;
+// workflows beyond that last page are not searched.
+func ResolveWorkflow(ctx context.Context, errOut io.Writer, client *api.Client, repo ghrepo.Interface, idOrName string) (*Workflow, error) {
+	id, idErr := strconv.Atoi(idOrName)
+
+	for page := 1; page <= maxResolvePages; page++ {
+		var result WorkflowsPayload
+
+		p := fmt.Sprintf("repos/%s/actions/workflows?per_page=100&page=%d", ghrepo.FullName(repo), page)
+
+		err := api.RetryOnRateLimit(ctx, errOut, api.DefaultMaxRateLimitRetries, func() error {
+			return client.REST(repo.RepoHost(), "GET", p, nil, &result)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workflow := range result.Workflows {
+			if idErr == nil && workflow.ID == id {
+				w := workflow
+				return &w, nil
+			}
+			if idErr != nil && workflowMatches(workflow, idOrName) {
+				w := workflow
+				return &w, nil
+			}
+		}
+
+		if len(result.Workflows) < 100 {
+			break
+		}
+	}
+
+	if idErr == nil {
+		return nil, fmt.Errorf("could not find any workflows with ID %d", id)
+	}
+	return nil, fmt.Errorf("could not find any workflows named %q", idOrName)
+}
+
+func workflowMatches(workflow Workflow, idOrName string) bool {
+	if strings.EqualFold(workflow.Name, idOrName) {
+		return true
+	}
+	return strings.EqualFold(workflow.Path, idOrName) || strings.EqualFold(path.Base(workflow.Path), idOrName)
+}
@@ -0,0 +1,31 @@
+package shared
+
+import "testing"
+
+func TestWorkflowMatches(t *testing.T) {
+	w := Workflow{Name: "Build and Test", Path: ".github/workflows/ci.yml"}
+
+	tests := []struct {
+		name     string
+		selector string
+		want     bool
+	}{
+		{name: "exact name", selector: "Build and Test", want: true},
+		{name: "case-insensitive name", selector: "build AND test", want: true},
+		{name: "full path", selector: ".github/workflows/ci.yml", want: true},
+		{name: "path case-insensitive", selector: ".GITHUB/WORKFLOWS/CI.YML", want: true},
+		{name: "basename only", selector: "ci.yml", want: true},
+		{name: "basename case-insensitive", selector: "CI.YML", want: true},
+		{name: "unrelated name", selector: "Deploy", want: false},
+		{name: "unrelated path", selector: "deploy.yml", want: false},
+		{name: "partial match is not a match", selector: "ci", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workflowMatches(w, tt.selector); got != tt.want {
+				t.Errorf("workflowMatches(%+v, %q) = %v, want %v", w, tt.selector, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cli/cli/api"
+	"github.com/cli/cli/internal/ghrepo"
+	"github.com/cli/cli/pkg/cmd/workflow/shared"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type ViewOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Context    context.Context
+
+	RunID int64
+}
+
+func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Command {
+	opts := &ViewOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "view <run-id>",
+		Short: "View a summary of a workflow run's jobs and steps",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+			opts.Context = cmd.Context()
+
+			runID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return &cmdutil.FlagError{Err: fmt.Errorf("invalid run ID: %v", args[0])}
+			}
+			opts.RunID = runID
+
+			if runF != nil {
+				return runF(opts)
+			}
+
+			return viewRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func viewRun(opts *ViewOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return fmt.Errorf("could not determine base repo: %w", err)
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("could not create http client: %w", err)
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	opts.IO.StartProgressIndicator()
+	run, err := shared.GetRun(opts.Context, opts.IO.ErrOut, client, repo, opts.RunID)
+	if err != nil {
+		opts.IO.StopProgressIndicator()
+		return fmt.Errorf("could not get run: %w", err)
+	}
+
+	jobs, err := shared.GetRunJobs(opts.Context, opts.IO.ErrOut, client, repo, opts.RunID)
+	opts.IO.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("could not get jobs for run: %w", err)
+	}
+
+	cs := opts.IO.ColorScheme()
+
+	fmt.Fprintf(opts.IO.Out, "%s %s · %s\n", cs.Bold(run.Name), run.Status, run.Conclusion)
+	fmt.Fprintf(opts.IO.Out, "%s\n\n", run.HTMLURL)
+
+	for _, job := range jobs {
+		fmt.Fprintf(opts.IO.Out, "%s (%s)\n", cs.Bold(job.Name), job.Conclusion)
+		for _, step := range job.Steps {
+			fmt.Fprintf(opts.IO.Out, "  %d. %s - %s\n", step.Number, step.Name, step.Conclusion)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	cmdArtifacts "github.com/cli/cli/pkg/cmd/workflow/artifacts"
+	cmdDisable "github.com/cli/cli/pkg/cmd/workflow/disable"
+	cmdEnable "github.com/cli/cli/pkg/cmd/workflow/enable"
+	cmdList "github.com/cli/cli/pkg/cmd/workflow/list"
+	cmdRun "github.com/cli/cli/pkg/cmd/workflow/run"
+	cmdRuns "github.com/cli/cli/pkg/cmd/workflow/runs"
+	cmdView "github.com/cli/cli/pkg/cmd/workflow/view"
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdWorkflow(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workflow <command>",
+		Short: "View details about GitHub Actions workflows",
+		Long:  "List, view, and manage runs for workflows in GitHub Actions.",
+		Annotations: map[string]string{
+			"IsActions": "true",
+		},
+	}
+
+	cmd.AddCommand(cmdList.NewCmdList(f, nil))
+	cmd.AddCommand(cmdRuns.NewCmdRuns(f, nil))
+	cmd.AddCommand(cmdView.NewCmdView(f, nil))
+	cmd.AddCommand(cmdArtifacts.NewCmdArtifacts(f, nil))
+	cmd.AddCommand(cmdEnable.NewCmdEnable(f, nil))
+	cmd.AddCommand(cmdDisable.NewCmdDisable(f, nil))
+	cmd.AddCommand(cmdRun.NewCmdRun(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,172 @@
+// Package jsonexport provides the --json/--jq/--template flag wiring
+// shared by commands that can emit machine-readable output. A command
+// declares which fields it supports, and callers select a subset (or
+// all) of them with --json, optionally post-processing the result with
+// a jq expression or a Go template.
+package jsonexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+// Exportable is implemented by the items a command wants to expose
+// through --json; fields is the subset the user asked for.
+type Exportable interface {
+	ExportData(fields []string) map[string]interface{}
+}
+
+// Exporter renders a slice of Exportable values as JSON, optionally
+// piping the result through a jq expression or a Go template.
+type Exporter struct {
+	fields   []string
+	jq       string
+	template string
+}
+
+func (e *Exporter) Fields() []string {
+	return e.fields
+}
+
+func (e *Exporter) Write(w io.Writer, items []Exportable) error {
+	data := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		data[i] = item.ExportData(e.fields)
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if e.jq != "" {
+		return filterJQ(w, buf, e.jq)
+	}
+	if e.template != "" {
+		return filterTemplate(w, buf, e.template)
+	}
+
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	_, err = w.Write([]byte("\n"))
+	return err
+}
+
+func filterJQ(w io.Writer, data []byte, expr string) error {
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid jq expression %q: %w", expr, err)
+	}
+
+	iter := query.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return err
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func filterTemplate(w io.Writer, data []byte, tpl string) error {
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	t, err := template.New("export").Parse(tpl)
+	if err != nil {
+		return fmt.Errorf("invalid template %q: %w", tpl, err)
+	}
+
+	return t.Execute(w, input)
+}
+
+// AddFlags adds --json, --jq, and --template to cmd. fields is the list
+// of field names that are valid for --json. When the user passes --json,
+// *exporter is set during PreRunE so the RunE implementation can check
+// whether machine-readable output was requested.
+func AddFlags(cmd *cobra.Command, exporter **Exporter, fields []string) {
+	f := cmd.Flags()
+	f.StringSlice("json", nil, fmt.Sprintf("Output JSON with the specified fields (%s)", strings.Join(fields, ",")))
+	f.String("jq", "", "Filter JSON output using a jq expression")
+	f.String("template", "", "Format JSON output using a Go template")
+
+	previousPreRunE := cmd.PreRunE
+	cmd.PreRunE = func(c *cobra.Command, args []string) error {
+		if previousPreRunE != nil {
+			if err := previousPreRunE(c, args); err != nil {
+				return err
+			}
+		}
+
+		requested, err := f.GetStringSlice("json")
+		if err != nil {
+			return err
+		}
+
+		jqExpr, _ := f.GetString("jq")
+		tpl, _ := f.GetString("template")
+
+		if len(requested) == 0 {
+			if jqExpr != "" || tpl != "" {
+				return cmdutil.FlagErrorf("--jq and --template require --json")
+			}
+			return nil
+		}
+
+		if err := validateFields(requested, fields); err != nil {
+			return err
+		}
+
+		*exporter = &Exporter{fields: requested, jq: jqExpr, template: tpl}
+		return nil
+	}
+}
+
+func validateFields(requested, allowed []string) error {
+	allowedSet := map[string]struct{}{}
+	for _, f := range allowed {
+		allowedSet[f] = struct{}{}
+	}
+
+	var unknown []string
+	for _, f := range requested {
+		if _, ok := allowedSet[f]; !ok {
+			unknown = append(unknown, f)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	sort.Strings(allowed)
+	return cmdutil.FlagErrorf("unknown JSON field: %s\nAvailable fields: %s", strings.Join(unknown, ", "), strings.Join(allowed, ", "))
+}
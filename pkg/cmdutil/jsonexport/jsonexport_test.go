@@ -0,0 +1,51 @@
+package jsonexport
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFields(t *testing.T) {
+	allowed := []string{"name", "id", "state"}
+
+	tests := []struct {
+		name      string
+		requested []string
+		wantErr   bool
+	}{
+		{name: "all allowed", requested: []string{"name", "state"}, wantErr: false},
+		{name: "single allowed", requested: []string{"id"}, wantErr: false},
+		{name: "unknown field", requested: []string{"name", "bogus"}, wantErr: true},
+		{name: "all unknown", requested: []string{"bogus", "also-bogus"}, wantErr: true},
+		{name: "empty requested", requested: []string{}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFields(tt.requested, allowed)
+			if tt.wantErr && err == nil {
+				t.Fatalf("validateFields(%v, %v) = nil, want error", tt.requested, allowed)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validateFields(%v, %v) = %v, want nil", tt.requested, allowed, err)
+			}
+		})
+	}
+}
+
+func TestValidateFieldsErrorListsUnknownAndAvailable(t *testing.T) {
+	allowed := []string{"name", "id", "state"}
+
+	err := validateFields([]string{"bogus", "id"}, allowed)
+	if err == nil {
+		t.Fatal("validateFields() = nil, want error")
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "bogus") {
+		t.Fatalf("error message %q does not mention the unknown field", msg)
+	}
+	if !strings.Contains(msg, "name") || !strings.Contains(msg, "id") || !strings.Contains(msg, "state") {
+		t.Fatalf("error message %q does not list the available fields", msg)
+	}
+}